@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchSizes mirrors the sizes swept by main's CLI harness.
+var benchSizes = []int{2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768}
+
+// benchMethods mirrors the testFunctions map in main.
+var benchMethods = map[string]func([]int, []int) int{
+	"xor_original":  findMissingXOROriginal,
+	"xor_optimized": findMissingXOROptimized,
+	"xor_parallel":  findMissingXORParallel,
+	"sum":           findMissingSum,
+	"linear":        findMissingLinear,
+	"set":           findMissingSet,
+	"sort":          findMissingSort,
+}
+
+// BenchmarkFindMissing runs every method against every size as its own
+// sub-benchmark so `go test -bench` output can be compared across commits
+// with benchstat instead of relying on the CLI's own timing table.
+func BenchmarkFindMissing(b *testing.B) {
+	for _, size := range benchSizes {
+		a, bb := generateTestData(size, 1)
+		for name, fn := range benchMethods {
+			fn := fn
+			b.Run(fmt.Sprintf("size=%d/method=%s", size, name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					fn(a, bb)
+				}
+			})
+		}
+	}
+}
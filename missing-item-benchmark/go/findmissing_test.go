@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestFindMissingMultiXOR_EqualValuePair locks in the k==2 fallback: when
+// the two missing values are equal, XOR(a)^XOR(b) is 0 and there's no set
+// bit to partition on, so findMissingMultiXOR must fall back to
+// findMissingByCount instead of returning the degenerate [0 0].
+func TestFindMissingMultiXOR_EqualValuePair(t *testing.T) {
+	a := []int{5, 5}
+	b := []int{}
+
+	got := findMissingMultiXOR(a, b)
+	want := []int{5, 5}
+
+	sort.Ints(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findMissingMultiXOR(%v, %v) = %v, want %v", a, b, got, want)
+	}
+}
+
+// TestFindMissingSort covers the smallest, middle, and largest missing
+// value, since the largest case is the only one that exercises the tail
+// return (sortedA[len(sortedB)]) rather than the loop's early return.
+func TestFindMissingSort(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want int
+	}{
+		{"missing smallest", []int{1, 2, 3, 4}, []int{2, 3, 4}, 1},
+		{"missing middle", []int{1, 2, 3, 4}, []int{1, 2, 4}, 3},
+		{"missing largest", []int{1, 2, 3, 4}, []int{1, 2, 3}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findMissingSort(tt.a, tt.b); got != tt.want {
+				t.Errorf("findMissingSort(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMissingXOR_Streaming checks that feeding elements incrementally via
+// AddA/AddB produces the same result as the batch XOR methods.
+func TestMissingXOR_Streaming(t *testing.T) {
+	a := []int{1, 2, 3, 4}
+	b := []int{1, 3, 4}
+
+	var m MissingXOR
+	for _, n := range a {
+		m.AddA(n)
+	}
+	for _, n := range b {
+		m.AddB(n)
+	}
+
+	if got, want := m.Result(), 2; got != want {
+		t.Errorf("MissingXOR.Result() = %d, want %d", got, want)
+	}
+}
+
+// TestFindMissingByPrefixSum checks the value guarantee that holds
+// unconditionally, and the index guarantee that only holds when the missing
+// value has no duplicate immediately following it in a.
+func TestFindMissingByPrefixSum(t *testing.T) {
+	t.Run("no adjacent duplicate: index and value both correct", func(t *testing.T) {
+		a := []int{7, 4, 5, 9}
+		b := []int{7, 5, 9}
+
+		idx, val := findMissingByPrefixSum(a, b)
+		if idx != 1 || val != 4 {
+			t.Errorf("findMissingByPrefixSum(%v, %v) = (%d, %d), want (1, 4)", a, b, idx, val)
+		}
+	})
+
+	t.Run("adjacent duplicate: value still correct, index lands on duplicate run's end", func(t *testing.T) {
+		a := []int{7, 4, 4, 9}
+		b := []int{7, 4, 9}
+
+		idx, val := findMissingByPrefixSum(a, b)
+		if idx != 2 || val != 4 {
+			t.Errorf("findMissingByPrefixSum(%v, %v) = (%d, %d), want (2, 4)", a, b, idx, val)
+		}
+	})
+}
@@ -3,9 +3,16 @@ package main
 import (
 	"fmt"
 	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
 	"time"
 )
 
+// runCount is how many timing samples are collected per method per size.
+// 100 samples is enough for the p99 bucket to mean something.
+const runCount = 100
+
 // timeFunction measures the execution time of a function
 func timeFunction(fn func([]int, []int) int, a, b []int) (int, time.Duration) {
 	start := time.Now()
@@ -14,6 +21,39 @@ func timeFunction(fn func([]int, []int) int, a, b []int) (int, time.Duration) {
 	return result, elapsed
 }
 
+// latencyStats summarizes a slice of timing samples.
+type latencyStats struct {
+	min, mean, p50, p95, p99, max time.Duration
+}
+
+// computeLatencyStats sorts a copy of samples and reports min, mean, and the
+// p50/p95/p99/max percentiles by indexing into the sorted slice, so a single
+// cold-cache outlier doesn't dominate the summary the way a plain average does.
+func computeLatencyStats(samples []time.Duration) latencyStats {
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, s := range sorted {
+		total += s
+	}
+
+	percentile := func(p float64) time.Duration {
+		idx := int(p * float64(len(sorted)-1))
+		return sorted[idx]
+	}
+
+	return latencyStats{
+		min:  sorted[0],
+		mean: total / time.Duration(len(sorted)),
+		p50:  percentile(0.50),
+		p95:  percentile(0.95),
+		p99:  percentile(0.99),
+		max:  sorted[len(sorted)-1],
+	}
+}
+
 // findMissingXOROriginal - XOR method using separate loops
 func findMissingXOROriginal(a, b []int) int {
 	xorA := 0
@@ -45,6 +85,75 @@ func findMissingXOROptimized(a, b []int) int {
 	return result
 }
 
+// xorAccumulator is the per-worker scratch state recycled by xorAccPool so the
+// parallel XOR path doesn't allocate a fresh accumulator per worker on every
+// call. The pool covers only the accumulators: the goroutines, the closures
+// they run and the shared result, mutex and WaitGroup still allocate per call.
+type xorAccumulator struct {
+	val int
+}
+
+var xorAccPool = sync.Pool{
+	New: func() interface{} { return new(xorAccumulator) },
+}
+
+// findMissingXORParallelShards shards a and b across shards goroutines, XORs
+// each shard into a pooled accumulator, and combines the partial results.
+// findMissingXORParallel calls this with runtime.NumCPU() shards; callers
+// that want to explore the crossover point against the serial XOR methods
+// can pick their own shard count directly.
+func findMissingXORParallelShards(a, b []int, shards int) int {
+	if shards < 1 {
+		shards = 1
+	}
+
+	result := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	xorShard := func(nums []int) {
+		defer wg.Done()
+
+		acc := xorAccPool.Get().(*xorAccumulator)
+		acc.val = 0
+		for _, num := range nums {
+			acc.val ^= num
+		}
+
+		mu.Lock()
+		result ^= acc.val
+		mu.Unlock()
+
+		xorAccPool.Put(acc)
+	}
+
+	shard := func(nums []int) {
+		if len(nums) == 0 {
+			return
+		}
+		chunkSize := (len(nums) + shards - 1) / shards
+		for start := 0; start < len(nums); start += chunkSize {
+			end := start + chunkSize
+			if end > len(nums) {
+				end = len(nums)
+			}
+			wg.Add(1)
+			go xorShard(nums[start:end])
+		}
+	}
+
+	shard(a)
+	shard(b)
+	wg.Wait()
+
+	return result
+}
+
+// findMissingXORParallel - XOR method sharded across runtime.NumCPU() goroutines
+func findMissingXORParallel(a, b []int) int {
+	return findMissingXORParallelShards(a, b, runtime.NumCPU())
+}
+
 // findMissingSum - Sum difference method
 func findMissingSum(a, b []int) int {
 	sumA := 0
@@ -108,25 +217,197 @@ func findMissingSet(a, b []int) int {
 	return 0 // Should never reach here if input is valid
 }
 
-// generateTestData creates test arrays with one missing element
-func generateTestData(size int) ([]int, []int) {
+// findMissingSort - sort-and-scan method, O(n log n). Unlike findMissingSum
+// it doesn't rely on arithmetic-overflow assumptions, and cache effects can
+// make it competitive with the O(n) methods for small/medium n.
+func findMissingSort(a, b []int) int {
+	sortedA := make([]int, len(a))
+	copy(sortedA, a)
+	sortedB := make([]int, len(b))
+	copy(sortedB, b)
+
+	sort.Ints(sortedA)
+	sort.Ints(sortedB)
+
+	for i := range sortedB {
+		if sortedA[i] != sortedB[i] {
+			return sortedA[i]
+		}
+	}
+
+	return sortedA[len(sortedB)]
+}
+
+// MissingXOR is a streaming variant of the XOR method for callers processing
+// data from channels or io.Reader pipelines that don't want to materialize
+// the full a and b slices.
+type MissingXOR struct {
+	acc int
+}
+
+// AddA folds an element of a into the running XOR.
+func (m *MissingXOR) AddA(n int) {
+	m.acc ^= n
+}
+
+// AddB folds an element of b into the running XOR.
+func (m *MissingXOR) AddB(n int) {
+	m.acc ^= n
+}
+
+// Result returns the missing element once every element of a and b has been added.
+func (m *MissingXOR) Result() int {
+	return m.acc
+}
+
+// findMissingMultiXOR generalizes the XOR trick to k = len(a) - len(b)
+// missing elements. For k == 1 it's the plain XOR method. For k == 2 it uses
+// the classic trick: XOR(a) ^ XOR(b) has a set bit wherever the two missing
+// values differ, so picking any one such bit partitions both arrays into two
+// groups that each contain exactly one missing value, which XOR recovers
+// independently. That trick degenerates when the two missing values are
+// equal (their XOR is 0, leaving no set bit to partition on), and for k > 2
+// it doesn't generalize at all, so both cases fall back to a map-difference
+// scan via findMissingByCount.
+func findMissingMultiXOR(a, b []int) []int {
+	k := len(a) - len(b)
+	if k <= 0 {
+		return nil
+	}
+
+	if k == 1 {
+		return []int{findMissingXOROptimized(a, b)}
+	}
+
+	if k == 2 {
+		x := 0
+		for _, n := range a {
+			x ^= n
+		}
+		for _, n := range b {
+			x ^= n
+		}
+
+		if x != 0 {
+			bit := x & -x // any set bit of x
+
+			var x1, x2 int
+			partition := func(n int) {
+				if n&bit != 0 {
+					x1 ^= n
+				} else {
+					x2 ^= n
+				}
+			}
+			for _, n := range a {
+				partition(n)
+			}
+			for _, n := range b {
+				partition(n)
+			}
+
+			return []int{x1, x2}
+		}
+		// x == 0: the two missing values are equal, so there's no set bit to
+		// partition on. Fall through to the map-difference method below.
+	}
+
+	return findMissingByCount(a, b)
+}
+
+// findMissingByCount computes the multiset of elements present in a but
+// missing from b via straightforward map counting. It's the map-difference
+// fallback for findMissingMultiXOR, and doubles as a brute-force oracle for
+// verifying the bit-tricks against.
+func findMissingByCount(a, b []int) []int {
+	countA := make(map[int]int)
+	for _, n := range a {
+		countA[n]++
+	}
+	countB := make(map[int]int)
+	for _, n := range b {
+		countB[n]++
+	}
+
+	var missing []int
+	for n, count := range countA {
+		for i := 0; i < count-countB[n]; i++ {
+			missing = append(missing, n)
+		}
+	}
+
+	return missing
+}
+
+// sameMultiset reports whether x and y contain the same elements with the
+// same multiplicities, ignoring order.
+func sameMultiset(x, y []int) bool {
+	if len(x) != len(y) {
+		return false
+	}
+
+	sx := make([]int, len(x))
+	copy(sx, x)
+	sy := make([]int, len(y))
+	copy(sy, y)
+	sort.Ints(sx)
+	sort.Ints(sy)
+
+	for i := range sx {
+		if sx[i] != sy[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findMissingByPrefixSum builds running sums of the raw (unsorted) a and b
+// and scans for the first index where they diverge, in O(n) with no
+// sorting. The returned value is always the true missing element: a and b
+// agree up to the removal point, so the sums can only diverge once a kept
+// element differs from the missing value, and until then the divergence
+// index still falls inside the missing value's own run of duplicates. The
+// returned index is only the missing element's original position when it
+// has no immediately following duplicate in a (a[index] != a[index+1]);
+// with adjacent duplicates the index instead lands on the last element of
+// that duplicate run.
+func findMissingByPrefixSum(a, b []int) (index, value int) {
+	sumA, sumB := 0, 0
+	for i := 0; i < len(b); i++ {
+		sumA += a[i]
+		sumB += b[i]
+		if sumA != sumB {
+			return i, a[i]
+		}
+	}
+
+	return len(b), a[len(b)]
+}
+
+// generateTestData creates test arrays with `missing` elements removed from b
+func generateTestData(size, missing int) ([]int, []int) {
 	// Create array a with random integers
 	a := make([]int, size)
 	for i := 0; i < size; i++ {
 		a[i] = rand.Intn(size)
 	}
 
-	// Create array b as copy of a, then remove one random element
+	// Create array b as copy of a, then remove `missing` random elements
 	b := make([]int, len(a))
 	copy(b, a)
 
-	// Remove a random element
-	removeIndex := rand.Intn(len(b))
-	b = append(b[:removeIndex], b[removeIndex+1:]...)
+	for i := 0; i < missing && len(b) > 0; i++ {
+		removeIndex := rand.Intn(len(b))
+		b = append(b[:removeIndex], b[removeIndex+1:]...)
+	}
 
 	return a, b
 }
 
+// main is a thin CLI wrapper around the same find* functions exercised by
+// BenchmarkFindMissing; for statistically rigorous comparisons across
+// commits, prefer `go test -bench . -benchmem` piped through benchstat.
 func main() {
 	// Test with different list sizes
 	sizes := []int{2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768}
@@ -135,30 +416,34 @@ func main() {
 	testFunctions := map[string]func([]int, []int) int{
 		"xor_original":  findMissingXOROriginal,
 		"xor_optimized": findMissingXOROptimized,
+		"xor_parallel":  findMissingXORParallel,
 		"sum":           findMissingSum,
 		"linear":        findMissingLinear,
 		"set":           findMissingSet,
+		"sort":          findMissingSort,
 	}
 
+	// methodOrder keeps the table output stable across runs; map iteration order isn't.
+	methodOrder := []string{"xor_original", "xor_optimized", "xor_parallel", "sum", "linear", "set", "sort"}
+
 	// Seed random number generator
 	rand.Seed(time.Now().UnixNano())
 
 	for _, size := range sizes {
 		fmt.Printf("\n=== Testing with list size: %d ===\n", size)
 
-		// Generate test data
-		a, b := generateTestData(size)
+		// Generate test data with a single missing element
+		a, b := generateTestData(size, 1)
 
-		// Run multiple times and average
+		// Collect the full timing distribution per method instead of just an average
 		timeResults := make(map[string][]time.Duration)
 		for key := range testFunctions {
-			timeResults[key] = make([]time.Duration, 0, 10)
+			timeResults[key] = make([]time.Duration, 0, runCount)
 		}
 
 		var results []int
 
-		// Run 10 times for better average
-		for i := 0; i < 10; i++ {
+		for i := 0; i < runCount; i++ {
 			for key, fn := range testFunctions {
 				result, elapsed := timeFunction(fn, a, b)
 				timeResults[key] = append(timeResults[key], elapsed)
@@ -168,43 +453,31 @@ func main() {
 			}
 		}
 
-		// Calculate averages
-		avgTimes := make(map[string]time.Duration)
-		for key, times := range timeResults {
-			var total time.Duration
-			for _, t := range times {
-				total += t
-			}
-			avgTimes[key] = total / time.Duration(len(times))
+		// Summarize each method's distribution
+		statsByMethod := make(map[string]latencyStats)
+		for key, samples := range timeResults {
+			statsByMethod[key] = computeLatencyStats(samples)
 		}
 
-		// Print results
-		fmt.Printf("Average time using XOR (original): %v\n", avgTimes["xor_original"])
-		fmt.Printf("Average time using XOR (optimized): %v\n", avgTimes["xor_optimized"])
-		fmt.Printf("Average time using Sum: %v\n", avgTimes["sum"])
-		fmt.Printf("Average time using Linear: %v\n", avgTimes["linear"])
-		fmt.Printf("Average time using Set: %v\n", avgTimes["set"])
+		// Print one row per method, one column per percentile
+		fmt.Printf("%-14s %12s %12s %12s %12s %12s %12s\n", "Method", "Min", "Mean", "P50", "P95", "P99", "Max")
+		for _, key := range methodOrder {
+			s := statsByMethod[key]
+			fmt.Printf("%-14s %12v %12v %12v %12v %12v %12v\n", key, s.min, s.mean, s.p50, s.p95, s.p99, s.max)
+		}
 
-		// Find the fastest method
+		// Find the fastest method by median (p50) so a single cold-cache outlier can't flip the winner
 		var fastestMethod string
 		var fastestTime time.Duration = time.Hour // Start with a very large time
 
-		times := map[string]time.Duration{
-			"XOR (original)":  avgTimes["xor_original"],
-			"XOR (optimized)": avgTimes["xor_optimized"],
-			"Sum":             avgTimes["sum"],
-			"Linear":          avgTimes["linear"],
-			"Set":             avgTimes["set"],
-		}
-
-		for method, t := range times {
-			if t < fastestTime {
-				fastestTime = t
-				fastestMethod = method
+		for _, key := range methodOrder {
+			if p50 := statsByMethod[key].p50; p50 < fastestTime {
+				fastestTime = p50
+				fastestMethod = key
 			}
 		}
 
-		fmt.Printf("Fastest method: %s (%v)\n", fastestMethod, fastestTime)
+		fmt.Printf("Fastest method (by median): %s (%v)\n", fastestMethod, fastestTime)
 
 		// Check for discrepancies - all results should be the same
 		firstResult := results[0]
@@ -226,5 +499,25 @@ func main() {
 		} else {
 			fmt.Printf("All methods returned the same result: %d\n", firstResult)
 		}
+
+		// value is always the true missing element; index is its original
+		// position only when a has no duplicate immediately after it.
+		idx, val := findMissingByPrefixSum(a, b)
+		fmt.Printf("findMissingByPrefixSum: value=%d at index=%d\n", val, idx)
+	}
+
+	// Sweep k = number of missing elements to exercise findMissingMultiXOR
+	// beyond the single-missing case.
+	fmt.Println("\n=== Multi-missing (k) sweep ===")
+	kSweepSizes := []int{1024, 8192, 32768}
+	kValues := []int{1, 2, 4, 8}
+
+	for _, size := range kSweepSizes {
+		for _, k := range kValues {
+			a, b := generateTestData(size, k)
+			got := findMissingMultiXOR(a, b)
+			want := findMissingByCount(a, b)
+			fmt.Printf("size=%d k=%d -> %d value(s) found: %v (matches known-removed set: %v)\n", size, k, len(got), got, sameMultiset(got, want))
+		}
 	}
 }